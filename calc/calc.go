@@ -0,0 +1,265 @@
+// Package calc implements the expression evaluation core shared by every
+// transport (HTTP, WebSocket, and gRPC): tokenizing, shunting-yard
+// parsing, and DAG-scheduled evaluation.
+package calc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidExpression     = errors.New("invalid expression")
+	ErrMismatchedParentheses = errors.New("mismatched parentheses")
+	ErrDivisionByZero        = errors.New("division by zero")
+	ErrUnknownFunction       = errors.New("unknown function")
+	ErrArityMismatch         = errors.New("wrong number of arguments")
+	ErrUnknownVariable       = errors.New("unknown variable")
+)
+
+// Calc evaluates expression to completion with no variables bound. It is a
+// convenience wrapper around CalcCtx for callers that have no need to
+// cancel.
+func Calc(expression string) (float64, error) {
+	return CalcCtx(context.Background(), expression)
+}
+
+// CalcCtx evaluates expression, aborting early if ctx is cancelled while a
+// worker goroutine is mid-evaluation.
+func CalcCtx(ctx context.Context, expression string) (float64, error) {
+	return evalCtx(ctx, expression, nil)
+}
+
+// CalcWithEnv evaluates expression with vars bound as its variable
+// environment.
+func CalcWithEnv(expression string, vars map[string]float64) (float64, error) {
+	return evalCtx(context.Background(), expression, vars)
+}
+
+func evalCtx(ctx context.Context, expression string, vars map[string]float64) (float64, error) {
+	if strings.TrimSpace(expression) == "" {
+		return 0, ErrInvalidExpression
+	}
+
+	tokens, err := Tokenize(expression)
+	if err != nil {
+		return 0, err
+	}
+	postfix, err := InfixToPostfix(tokens)
+	if err != nil {
+		return 0, err
+	}
+	return evaluatePostfix(ctx, postfix, vars)
+}
+
+func isIdentStart(c rune) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentPart(c rune) bool {
+	return isIdentStart(c) || (c >= '0' && c <= '9')
+}
+
+// Tokenize splits expr into numbers, identifiers, operators, parentheses and
+// commas, discarding whitespace. It returns ErrInvalidExpression on any
+// character that doesn't belong to the grammar.
+func Tokenize(expr string) ([]string, error) {
+	var tokens []string
+	var number strings.Builder
+
+	flushNumber := func() {
+		if number.Len() > 0 {
+			tokens = append(tokens, number.String())
+			number.Reset()
+		}
+	}
+
+	runes := []rune(expr)
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		switch {
+		case c == ' ' || c == '\t':
+			flushNumber()
+		case c == '+' || c == '-' || c == '*' || c == '/' || c == '^' || c == '%' || c == '(' || c == ')' || c == ',':
+			flushNumber()
+			tokens = append(tokens, string(c))
+		case c >= '0' && c <= '9' || c == '.':
+			number.WriteRune(c)
+		case isIdentStart(c):
+			flushNumber()
+			var ident strings.Builder
+			for i < len(runes) && isIdentPart(runes[i]) {
+				ident.WriteRune(runes[i])
+				i++
+			}
+			i--
+			tokens = append(tokens, ident.String())
+		default:
+			return nil, ErrInvalidExpression
+		}
+	}
+	flushNumber()
+
+	return tokens, nil
+}
+
+// funcCall tracks the name and argument count of a function call while
+// InfixToPostfix is still inside its parentheses.
+type funcCall struct {
+	name  string
+	count int
+}
+
+// InfixToPostfix runs the shunting-yard algorithm over tokens, producing RPN
+// output. It supports right-associative exponentiation, unary minus, and
+// function calls with a parallel argument-count stack pushed on "(" and
+// incremented on each top-level ",".
+func InfixToPostfix(tokens []string) ([]string, error) {
+	var output []string
+	var oper []string
+	var parenIsCall []bool
+	var calls []*funcCall
+	pendingFunc := ""
+
+	push := func(token string) {
+		for len(oper) > 0 && oper[len(oper)-1] != "(" &&
+			(precedence(oper[len(oper)-1]) > precedence(token) ||
+				(precedence(oper[len(oper)-1]) == precedence(token) && !rightAssociative(token))) {
+			output = append(output, oper[len(oper)-1])
+			oper = oper[:len(oper)-1]
+		}
+		oper = append(oper, token)
+	}
+
+	for i, token := range tokens {
+		switch {
+		case isNumber(token):
+			output = append(output, token)
+		case isIdentifier(token):
+			if i+1 < len(tokens) && tokens[i+1] == "(" {
+				pendingFunc = token
+			} else {
+				output = append(output, token)
+			}
+		case token == ",":
+			for len(oper) > 0 && oper[len(oper)-1] != "(" {
+				output = append(output, oper[len(oper)-1])
+				oper = oper[:len(oper)-1]
+			}
+			if len(oper) == 0 || !parenIsCall[len(parenIsCall)-1] {
+				return nil, ErrInvalidExpression
+			}
+			calls[len(calls)-1].count++
+		case token == "(":
+			oper = append(oper, token)
+			if pendingFunc != "" {
+				parenIsCall = append(parenIsCall, true)
+				calls = append(calls, &funcCall{name: pendingFunc, count: 1})
+				pendingFunc = ""
+			} else {
+				parenIsCall = append(parenIsCall, false)
+			}
+		case token == ")":
+			for len(oper) > 0 && oper[len(oper)-1] != "(" {
+				output = append(output, oper[len(oper)-1])
+				oper = oper[:len(oper)-1]
+			}
+			if len(oper) == 0 {
+				return nil, ErrMismatchedParentheses
+			}
+			oper = oper[:len(oper)-1]
+
+			isCall := parenIsCall[len(parenIsCall)-1]
+			parenIsCall = parenIsCall[:len(parenIsCall)-1]
+			if isCall {
+				call := calls[len(calls)-1]
+				calls = calls[:len(calls)-1]
+				output = append(output, fmt.Sprintf("%s#%d", call.name, call.count))
+			}
+		case token == "-" && isUnaryContext(tokens, i):
+			push("u-")
+		case isOperator(token):
+			push(token)
+		default:
+			return nil, ErrInvalidExpression
+		}
+	}
+
+	for len(oper) > 0 {
+		if oper[len(oper)-1] == "(" {
+			return nil, ErrMismatchedParentheses
+		}
+		output = append(output, oper[len(oper)-1])
+		oper = oper[:len(oper)-1]
+	}
+
+	return output, nil
+}
+
+// isUnaryContext reports whether the "-" at tokens[i] is a unary minus: it
+// is one when nothing precedes it, or when it follows an operator, "(" or
+// ",".
+func isUnaryContext(tokens []string, i int) bool {
+	if i == 0 {
+		return true
+	}
+	prev := tokens[i-1]
+	return isOperator(prev) || prev == "u-" || prev == "(" || prev == ","
+}
+
+// evaluatePostfix walks the RPN one operator, function, or variable at a
+// time on a pool of worker goroutines: it builds a task graph where each
+// node depends on its operands, then hands that graph to a scheduler to
+// dispatch.
+func evaluatePostfix(ctx context.Context, postfix []string, vars map[string]float64) (float64, error) {
+	root, err := buildTaskGraph(postfix)
+	if err != nil {
+		return 0, err
+	}
+	return newScheduler(computingPower(), vars, defaultFunctions()).run(ctx, root)
+}
+
+func isNumber(token string) bool {
+	if _, err := strconv.ParseFloat(token, 64); err == nil {
+		return true
+	}
+	return false
+}
+
+func isIdentifier(token string) bool {
+	if token == "" || !isIdentStart(rune(token[0])) {
+		return false
+	}
+	for _, c := range token {
+		if !isIdentPart(c) {
+			return false
+		}
+	}
+	return true
+}
+
+func isOperator(token string) bool {
+	return token == "+" || token == "-" || token == "*" || token == "/" || token == "^" || token == "%"
+}
+
+func rightAssociative(op string) bool {
+	return op == "^" || op == "u-"
+}
+
+func precedence(op string) int {
+	switch op {
+	case "+", "-":
+		return 1
+	case "*", "/", "%":
+		return 2
+	case "^":
+		return 3
+	case "u-":
+		return 4
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,70 @@
+package calc
+
+import "math"
+
+// function evaluates a call once its arguments have been resolved.
+type function func(args []float64) (float64, error)
+
+// defaultFunctions is the registry CalcWithEnv and friends preload
+// evaluations with.
+func defaultFunctions() map[string]function {
+	return map[string]function{
+		"sin":  unaryFunc(math.Sin),
+		"cos":  unaryFunc(math.Cos),
+		"tan":  unaryFunc(math.Tan),
+		"sqrt": unaryFunc(math.Sqrt),
+		"log":  unaryFunc(math.Log10),
+		"ln":   unaryFunc(math.Log),
+		"abs":  unaryFunc(math.Abs),
+		"pow":  binaryFunc(math.Pow),
+		"min":  variadicFunc(minOf),
+		"max":  variadicFunc(maxOf),
+	}
+}
+
+func unaryFunc(f func(float64) float64) function {
+	return func(args []float64) (float64, error) {
+		if len(args) != 1 {
+			return 0, ErrArityMismatch
+		}
+		return f(args[0]), nil
+	}
+}
+
+func binaryFunc(f func(float64, float64) float64) function {
+	return func(args []float64) (float64, error) {
+		if len(args) != 2 {
+			return 0, ErrArityMismatch
+		}
+		return f(args[0], args[1]), nil
+	}
+}
+
+func variadicFunc(f func([]float64) float64) function {
+	return func(args []float64) (float64, error) {
+		if len(args) == 0 {
+			return 0, ErrArityMismatch
+		}
+		return f(args), nil
+	}
+}
+
+func minOf(args []float64) float64 {
+	m := args[0]
+	for _, v := range args[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
+
+func maxOf(args []float64) float64 {
+	m := args[0]
+	for _, v := range args[1:] {
+		if v > m {
+			m = v
+		}
+	}
+	return m
+}
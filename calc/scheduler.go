@@ -0,0 +1,237 @@
+package calc
+
+import (
+	"context"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultComputingPower = 4
+
+// computingPower reads the worker pool size from COMPUTING_POWER, falling
+// back to defaultComputingPower when unset or invalid.
+func computingPower() int {
+	n, err := strconv.Atoi(os.Getenv("COMPUTING_POWER"))
+	if err != nil || n < 1 {
+		return defaultComputingPower
+	}
+	return n
+}
+
+// opDelay returns the configured artificial latency for op, read fresh on
+// every call so the knobs can be tuned without restarting the process. Only
+// the four original arithmetic operators have a configurable delay.
+func opDelay(op string) time.Duration {
+	var key string
+	switch op {
+	case "+":
+		key = "TIME_ADDITION_MS"
+	case "-":
+		key = "TIME_SUBTRACTION_MS"
+	case "*":
+		key = "TIME_MULTIPLICATIONS_MS"
+	case "/":
+		key = "TIME_DIVISIONS_MS"
+	}
+	ms, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || ms < 0 {
+		return 0
+	}
+	return time.Duration(ms) * time.Millisecond
+}
+
+type nodeKind int
+
+const (
+	nodeNumber nodeKind = iota
+	nodeVariable
+	nodeUnary
+	nodeBinary
+	nodeFunction
+)
+
+// opNode is one node of the task graph built from a postfix expression. A
+// number or variable is a leaf; a unary, binary, or function node depends on
+// the operand nodes it was popped with.
+type opNode struct {
+	kind  nodeKind
+	value float64
+	name  string
+	args  []*opNode
+}
+
+// buildTaskGraph turns a postfix token stream into the DAG the scheduler
+// walks: numbers and variables become leaves, "u-" becomes a one-argument node,
+// binary operators become two-argument nodes, and "name#count" tokens
+// (emitted by InfixToPostfix for function calls) become count-argument
+// nodes.
+func buildTaskGraph(postfix []string) (*opNode, error) {
+	var stack []*opNode
+
+	pop := func(n int) ([]*opNode, bool) {
+		if len(stack) < n {
+			return nil, false
+		}
+		args := append([]*opNode(nil), stack[len(stack)-n:]...)
+		stack = stack[:len(stack)-n]
+		return args, true
+	}
+
+	for _, token := range postfix {
+		switch {
+		case isNumber(token):
+			num, _ := strconv.ParseFloat(token, 64)
+			stack = append(stack, &opNode{kind: nodeNumber, value: num})
+		case token == "u-":
+			args, ok := pop(1)
+			if !ok {
+				return nil, ErrInvalidExpression
+			}
+			stack = append(stack, &opNode{kind: nodeUnary, name: token, args: args})
+		case isOperator(token):
+			args, ok := pop(2)
+			if !ok {
+				return nil, ErrInvalidExpression
+			}
+			stack = append(stack, &opNode{kind: nodeBinary, name: token, args: args})
+		default:
+			if name, count, ok := parseFunctionToken(token); ok {
+				args, ok := pop(count)
+				if !ok {
+					return nil, ErrInvalidExpression
+				}
+				stack = append(stack, &opNode{kind: nodeFunction, name: name, args: args})
+				continue
+			}
+			if !isIdentifier(token) {
+				return nil, ErrInvalidExpression
+			}
+			stack = append(stack, &opNode{kind: nodeVariable, name: token})
+		}
+	}
+
+	if len(stack) != 1 {
+		return nil, ErrInvalidExpression
+	}
+	return stack[0], nil
+}
+
+// parseFunctionToken splits a "name#count" token produced by InfixToPostfix
+// for a function call back into its name and argument count.
+func parseFunctionToken(token string) (name string, count int, ok bool) {
+	i := strings.LastIndexByte(token, '#')
+	if i < 0 {
+		return "", 0, false
+	}
+	n, err := strconv.Atoi(token[i+1:])
+	if err != nil {
+		return "", 0, false
+	}
+	return token[:i], n, true
+}
+
+// scheduler dispatches ready nodes onto a bounded pool of worker goroutines,
+// via a buffered channel of slots, blocking producers once the pool is
+// saturated. It also carries the variable environment and function registry
+// a single evaluation run was invoked with.
+type scheduler struct {
+	slots chan struct{}
+	vars  map[string]float64
+	funcs map[string]function
+}
+
+func newScheduler(workers int, vars map[string]float64, funcs map[string]function) *scheduler {
+	return &scheduler{slots: make(chan struct{}, workers), vars: vars, funcs: funcs}
+}
+
+// run evaluates the DAG rooted at n one node at a time: its operands are
+// resolved concurrently (recursing through their own subgraphs), and only
+// once they're ready is n itself dispatched to a worker slot. It aborts as
+// soon as ctx is cancelled.
+func (s *scheduler) run(ctx context.Context, n *opNode) (float64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	switch n.kind {
+	case nodeNumber:
+		return n.value, nil
+	case nodeVariable:
+		v, ok := s.vars[n.name]
+		if !ok {
+			return 0, ErrUnknownVariable
+		}
+		return v, nil
+	}
+
+	vals := make([]float64, len(n.args))
+	errs := make([]error, len(n.args))
+	var wg sync.WaitGroup
+	wg.Add(len(n.args))
+	for i, arg := range n.args {
+		i, arg := i, arg
+		go func() {
+			defer wg.Done()
+			vals[i], errs[i] = s.run(ctx, arg)
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	select {
+	case s.slots <- struct{}{}:
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+	defer func() { <-s.slots }()
+
+	select {
+	case <-time.After(opDelay(n.name)):
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+
+	switch n.kind {
+	case nodeUnary:
+		return -vals[0], nil
+	case nodeFunction:
+		fn, ok := s.funcs[n.name]
+		if !ok {
+			return 0, ErrUnknownFunction
+		}
+		return fn(vals)
+	default:
+		return evalBinary(n.name, vals[0], vals[1])
+	}
+}
+
+func evalBinary(op string, a, b float64) (float64, error) {
+	switch op {
+	case "+":
+		return a + b, nil
+	case "-":
+		return a - b, nil
+	case "*":
+		return a * b, nil
+	case "/":
+		if b == 0 {
+			return 0, ErrDivisionByZero
+		}
+		return a / b, nil
+	case "^":
+		return math.Pow(a, b), nil
+	case "%":
+		return math.Mod(a, b), nil
+	default:
+		return 0, ErrInvalidExpression
+	}
+}
@@ -0,0 +1,96 @@
+package calc
+
+import "context"
+
+// Step reports one reduction of a step-by-step evaluation: A and B are the
+// operands (B is 0 for a unary minus, and for a function call they're its
+// first two arguments, 0 if it took fewer), and Partial is the result.
+type Step struct {
+	Op      string
+	A, B    float64
+	Partial float64
+}
+
+// StepEval evaluates expression one reduction at a time, walking the same
+// task graph buildTaskGraph/evaluatePostfix use, so unary minus, ^, %,
+// functions, and variables all work exactly as they do through Calc. It
+// invokes onStep after every unary, binary, or function node resolves. It
+// is the single engine behind every transport that needs partial results —
+// the WebSocket stream and the gRPC streaming RPC both call it — so they
+// stay in lockstep with each other. It aborts as soon as ctx is cancelled.
+//
+// Unlike evaluatePostfix, nodes are walked one at a time in program order
+// rather than fanned out to the scheduler's worker pool, since step
+// reporting needs a deterministic sequence of partial results.
+func StepEval(ctx context.Context, expression string, vars map[string]float64, onStep func(Step)) (float64, error) {
+	tokens, err := Tokenize(expression)
+	if err != nil {
+		return 0, err
+	}
+	postfix, err := InfixToPostfix(tokens)
+	if err != nil {
+		return 0, err
+	}
+	root, err := buildTaskGraph(postfix)
+	if err != nil {
+		return 0, err
+	}
+	return stepWalk(ctx, root, vars, defaultFunctions(), onStep)
+}
+
+func stepWalk(ctx context.Context, n *opNode, vars map[string]float64, funcs map[string]function, onStep func(Step)) (float64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	switch n.kind {
+	case nodeNumber:
+		return n.value, nil
+	case nodeVariable:
+		v, ok := vars[n.name]
+		if !ok {
+			return 0, ErrUnknownVariable
+		}
+		return v, nil
+	}
+
+	vals := make([]float64, len(n.args))
+	for i, arg := range n.args {
+		v, err := stepWalk(ctx, arg, vars, funcs, onStep)
+		if err != nil {
+			return 0, err
+		}
+		vals[i] = v
+	}
+
+	var (
+		partial float64
+		err     error
+	)
+	switch n.kind {
+	case nodeUnary:
+		partial = -vals[0]
+	case nodeFunction:
+		fn, ok := funcs[n.name]
+		if !ok {
+			return 0, ErrUnknownFunction
+		}
+		partial, err = fn(vals)
+	default:
+		partial, err = evalBinary(n.name, vals[0], vals[1])
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var a, b float64
+	if len(vals) > 0 {
+		a = vals[0]
+	}
+	if len(vals) > 1 {
+		b = vals[1]
+	}
+	onStep(Step{Op: n.name, A: a, B: b, Partial: partial})
+
+	return partial, nil
+}
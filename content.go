@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var errUnsupportedMediaType = errors.New("unsupported content type")
+
+// xmlRequest is the <request><expression>...</expression></request> shape
+// accepted alongside JSON, form and plain-text bodies.
+type xmlRequest struct {
+	XMLName    xml.Name `xml:"request"`
+	Expression string   `xml:"expression"`
+}
+
+// jobXML mirrors Job for application/xml responses.
+type jobXML struct {
+	XMLName xml.Name  `xml:"job"`
+	ID      string    `xml:"id"`
+	Status  JobStatus `xml:"status"`
+	Result  *float64  `xml:"result,omitempty"`
+	Error   *string   `xml:"error,omitempty"`
+}
+
+// createdXML is the application/xml shape for a freshly submitted job.
+type createdXML struct {
+	XMLName xml.Name `xml:"job"`
+	ID      string   `xml:"id"`
+}
+
+// parseExpression extracts the expression to evaluate from the request
+// body, honoring Content-Type: application/json (the {"expression":...}
+// shape, and the default when the header is absent), form-urlencoded,
+// text/plain (the raw body), and application/xml.
+func parseExpression(r *http.Request) (string, error) {
+	contentType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		contentType = "application/json"
+	}
+
+	switch contentType {
+	case "", "application/json":
+		var req Request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return "", err
+		}
+		return req.Expression, nil
+	case "application/x-www-form-urlencoded":
+		if err := r.ParseForm(); err != nil {
+			return "", err
+		}
+		return r.FormValue("expression"), nil
+	case "text/plain":
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(body)), nil
+	case "application/xml", "text/xml":
+		var req xmlRequest
+		if err := xml.NewDecoder(r.Body).Decode(&req); err != nil {
+			return "", err
+		}
+		return req.Expression, nil
+	default:
+		return "", errUnsupportedMediaType
+	}
+}
+
+// negotiateContentType picks a response representation from the Accept
+// header, defaulting to JSON when the header is absent, "*/*", or anything
+// else unrecognized.
+func negotiateContentType(r *http.Request) string {
+	accept := r.Header.Get("Accept")
+	switch {
+	case strings.Contains(accept, "text/plain"):
+		return "text/plain"
+	case strings.Contains(accept, "application/xml"):
+		return "application/xml"
+	default:
+		return "application/json"
+	}
+}
+
+// writeCreated renders a freshly submitted job's id in whatever
+// representation the request negotiated.
+func writeCreated(w http.ResponseWriter, r *http.Request, id string) {
+	switch negotiateContentType(r) {
+	case "text/plain":
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprintln(w, id)
+	case "application/xml":
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(http.StatusAccepted)
+		_ = xml.NewEncoder(w).Encode(createdXML{ID: id})
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		_ = json.NewEncoder(w).Encode(map[string]string{"id": id})
+	}
+}
+
+// writeJob renders job in whatever representation the request negotiated.
+// text/plain is for CLI users: it prints the bare result once the job is
+// done, the error message once it has failed, or the status while it's
+// still pending or running.
+func writeJob(w http.ResponseWriter, r *http.Request, job *Job) {
+	switch negotiateContentType(r) {
+	case "text/plain":
+		w.Header().Set("Content-Type", "text/plain")
+		switch {
+		case job.Result != nil:
+			fmt.Fprintln(w, strconv.FormatFloat(*job.Result, 'g', -1, 64))
+		case job.Error != nil:
+			fmt.Fprintln(w, *job.Error)
+		default:
+			fmt.Fprintln(w, job.Status)
+		}
+	case "application/xml":
+		w.Header().Set("Content-Type", "application/xml")
+		_ = xml.NewEncoder(w).Encode(jobXML{ID: job.ID, Status: job.Status, Result: job.Result, Error: job.Error})
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(job)
+	}
+}
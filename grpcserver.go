@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/uncomonq/final-assignment/calc"
+	"github.com/uncomonq/final-assignment/pb"
+)
+
+// calculatorServer implements pb.CalculatorServer on top of the same
+// calc.CalcWithEnv/calc.StepEval core the HTTP and WebSocket handlers use,
+// so all three transports agree on what an expression evaluates to.
+type calculatorServer struct{}
+
+func (calculatorServer) Calculate(ctx context.Context, req *pb.CalcRequest) (*pb.CalcResponse, error) {
+	result, err := calc.CalcWithEnv(req.GetExpression(), req.GetVars())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &pb.CalcResponse{Result: result}, nil
+}
+
+func (calculatorServer) CalculateStream(req *pb.CalcRequest, stream pb.Calculator_CalculateStreamServer) error {
+	_, err := calc.StepEval(stream.Context(), req.GetExpression(), req.GetVars(), func(step calc.Step) {
+		_ = stream.Send(&pb.CalcStep{Op: step.Op, A: step.A, B: step.B, Partial: step.Partial})
+	})
+	if err != nil {
+		return grpcError(err)
+	}
+	return nil
+}
+
+// grpcError maps calc's sentinel errors onto the gRPC status codes callers
+// are expected to branch on.
+func grpcError(err error) error {
+	switch {
+	case errors.Is(err, calc.ErrInvalidExpression), errors.Is(err, calc.ErrMismatchedParentheses):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, calc.ErrDivisionByZero):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func grpcAddr() string {
+	if a := os.Getenv("GRPC_ADDR"); a != "" {
+		return a
+	}
+	return ":9090"
+}
+
+// serveGRPC starts the gRPC server and blocks until it exits.
+func serveGRPC() {
+	lis, err := net.Listen("tcp", grpcAddr())
+	if err != nil {
+		fmt.Println("Failed to listen for gRPC:", err)
+		return
+	}
+
+	s := grpc.NewServer()
+	pb.RegisterCalculatorServer(s, calculatorServer{})
+
+	fmt.Println("gRPC server is running on", grpcAddr())
+	if err := s.Serve(lis); err != nil {
+		fmt.Println("gRPC server stopped:", err)
+	}
+}
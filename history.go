@@ -0,0 +1,57 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/uncomonq/final-assignment/store"
+)
+
+// handleHistory lists the authenticated user's past expressions, most
+// recent first.
+func handleHistory(w http.ResponseWriter, r *http.Request) {
+	user := userFromContext(r)
+
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = -1 // SQLite treats a negative LIMIT as "no limit".
+	}
+	offset, err := strconv.Atoi(r.URL.Query().Get("offset"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	exprs, err := db.ListExpressions(r.Context(), user.ID, limit, offset)
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(exprs)
+}
+
+// handleDeleteHistoryEntry removes one of the authenticated user's past
+// expressions.
+func handleDeleteHistoryEntry(w http.ResponseWriter, r *http.Request) {
+	user := userFromContext(r)
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid id", http.StatusBadRequest)
+		return
+	}
+
+	if err := db.DeleteExpression(r.Context(), user.ID, id); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			http.Error(w, "Not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,115 @@
+package main
+
+import "sync"
+
+// JobStatus is the lifecycle state of an asynchronously evaluated expression.
+type JobStatus string
+
+const (
+	JobPending    JobStatus = "pending"
+	JobInProgress JobStatus = "in_progress"
+	JobDone       JobStatus = "done"
+	JobError      JobStatus = "error"
+)
+
+// Job is the externally visible record for one /api/v1/calculate submission.
+type Job struct {
+	ID     string    `json:"id"`
+	Status JobStatus `json:"status"`
+	Result *float64  `json:"result,omitempty"`
+	Error  *string   `json:"error,omitempty"`
+
+	// UserID is the submitter, never serialized: List and Get are always
+	// scoped to the caller's own jobs.
+	UserID int64 `json:"-"`
+}
+
+// JobStore persists job state behind an interface so an in-memory store can
+// later be swapped for a DB-backed one without touching the handlers. Get
+// and List are scoped to userID so one user can never see another's jobs.
+type JobStore interface {
+	Create(id string, userID int64) *Job
+	Get(id string, userID int64) (*Job, bool)
+	List(userID int64) []*Job
+	SetInProgress(id string)
+	SetResult(id string, result float64)
+	SetError(id string, err error)
+}
+
+// memoryJobStore is a thread-safe in-memory JobStore. Get and List return
+// copies, so callers never read a *Job that Set* is still mutating.
+type memoryJobStore struct {
+	mu    sync.RWMutex
+	jobs  map[string]*Job
+	order []string
+}
+
+func newMemoryJobStore() *memoryJobStore {
+	return &memoryJobStore{jobs: make(map[string]*Job)}
+}
+
+func (s *memoryJobStore) Create(id string, userID int64) *Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job := &Job{ID: id, Status: JobPending, UserID: userID}
+	s.jobs[id] = job
+	s.order = append(s.order, id)
+	return job
+}
+
+func (s *memoryJobStore) Get(id string, userID int64) (*Job, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	job, ok := s.jobs[id]
+	if !ok || job.UserID != userID {
+		return nil, false
+	}
+	c := *job
+	return &c, true
+}
+
+func (s *memoryJobStore) List(userID int64) []*Job {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	jobs := make([]*Job, 0, len(s.order))
+	for _, id := range s.order {
+		if job := s.jobs[id]; job.UserID == userID {
+			c := *job
+			jobs = append(jobs, &c)
+		}
+	}
+	return jobs
+}
+
+func (s *memoryJobStore) SetInProgress(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if job, ok := s.jobs[id]; ok {
+		job.Status = JobInProgress
+	}
+}
+
+func (s *memoryJobStore) SetResult(id string, result float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if job, ok := s.jobs[id]; ok {
+		job.Status = JobDone
+		job.Result = &result
+	}
+}
+
+func (s *memoryJobStore) SetError(id string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if job, ok := s.jobs[id]; ok {
+		msg := err.Error()
+		job.Status = JobError
+		job.Error = &msg
+	}
+}
@@ -1,64 +1,124 @@
-package main
-
-import (
-	"encoding/json"
-	"errors"
-	"fmt"
-	"net/http"
-)
-
-type Request struct {
-	Expression string `json:"expression"`
-}
-
-type Response struct {
-	Result *float64 `json:"result,omitempty"`
-	Error  *string  `json:"error,omitempty"`
-}
-
-func handleCalculate(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
-		return
-	}
-
-	var req Request
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, `{"error":"Invalid JSON"}`, http.StatusBadRequest)
-		return
-	}
-
-	result, err := Calc(req.Expression)
-	response := Response{}
-
-	if err != nil {
-		if errors.Is(err, ErrInvalidExpression) || errors.Is(err, ErrMismatchedParentheses) {
-			msg := "Expression is not valid"
-			response.Error = &msg
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusUnprocessableEntity)
-			_ = json.NewEncoder(w).Encode(response)
-			return
-		} else {
-			msg := "Internal server error"
-			response.Error = &msg
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-			_ = json.NewEncoder(w).Encode(response)
-			return
-		}
-	}
-
-	response.Result = &result
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	_ = json.NewEncoder(w).Encode(response)
-}
-
-func main() {
-	http.HandleFunc("/api/v1/calculate", handleCalculate)
-	fmt.Println("Server is running on port 8080...")
-	if err := http.ListenAndServe(":8080", nil); err != nil {
-		fmt.Println("Failed to start server:", err)
-	}
-}
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/google/uuid"
+
+	"github.com/uncomonq/final-assignment/calc"
+	"github.com/uncomonq/final-assignment/store"
+	"github.com/uncomonq/final-assignment/ws"
+)
+
+type Request struct {
+	Expression string `json:"expression"`
+}
+
+var jobs JobStore = newMemoryJobStore()
+
+// handleCalculate submits an expression for asynchronous evaluation and
+// immediately returns its job id; the result is fetched later via
+// GET /api/v1/expressions/{id}. The caller must be authenticated: the
+// evaluation is recorded against their history once it completes.
+func handleCalculate(w http.ResponseWriter, r *http.Request) {
+	user := userFromContext(r)
+
+	expression, err := parseExpression(r)
+	if err != nil {
+		if errors.Is(err, errUnsupportedMediaType) {
+			http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+			return
+		}
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	id := uuid.NewString()
+	jobs.Create(id, user.ID)
+	go runJob(id, user.ID, expression)
+
+	writeCreated(w, r, id)
+}
+
+// runJob evaluates expression, records the outcome against id, and persists
+// it to the user's history. It runs in its own goroutine, kicked off by
+// handleCalculate, so it uses context.Background() rather than the
+// request's context, which is cancelled as soon as the request returns.
+func runJob(id string, userID int64, expression string) {
+	jobs.SetInProgress(id)
+
+	result, evalErr := calc.Calc(expression)
+
+	var resultPtr *float64
+	var errMsg *string
+	if evalErr != nil {
+		jobs.SetError(id, evalErr)
+		msg := evalErr.Error()
+		errMsg = &msg
+	} else {
+		jobs.SetResult(id, result)
+		resultPtr = &result
+	}
+
+	if _, err := db.SaveExpression(context.Background(), userID, expression, resultPtr, errMsg); err != nil {
+		fmt.Println("Failed to persist expression history:", err)
+	}
+}
+
+// handleListExpressions lists the authenticated user's own submitted jobs.
+func handleListExpressions(w http.ResponseWriter, r *http.Request) {
+	user := userFromContext(r)
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(jobs.List(user.ID))
+}
+
+// handleGetExpression fetches one of the authenticated user's own jobs.
+func handleGetExpression(w http.ResponseWriter, r *http.Request) {
+	user := userFromContext(r)
+
+	job, ok := jobs.Get(r.PathValue("id"), user.ID)
+	if !ok {
+		http.Error(w, `{"error":"Job not found"}`, http.StatusNotFound)
+		return
+	}
+
+	writeJob(w, r, job)
+}
+
+func dbPath() string {
+	if p := os.Getenv("DB_PATH"); p != "" {
+		return p
+	}
+	return "calculator.db"
+}
+
+func main() {
+	if sqliteStore, err := store.Open(dbPath()); err != nil {
+		fmt.Println("Failed to open SQLite store, falling back to in-memory:", err)
+	} else {
+		db = sqliteStore
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v1/register", handleRegister)
+	mux.HandleFunc("POST /api/v1/login", handleLogin)
+	mux.HandleFunc("POST /api/v1/calculate", requireAuth(handleCalculate))
+	mux.HandleFunc("GET /api/v1/expressions", requireAuth(handleListExpressions))
+	mux.HandleFunc("GET /api/v1/expressions/{id}", requireAuth(handleGetExpression))
+	mux.HandleFunc("GET /api/v1/history", requireAuth(handleHistory))
+	mux.HandleFunc("DELETE /api/v1/history/{id}", requireAuth(handleDeleteHistoryEntry))
+	mux.HandleFunc("GET /api/v1/stream/{session}", ws.Handler)
+
+	go serveGRPC()
+
+	fmt.Println("Server is running on port 8080...")
+	if err := http.ListenAndServe(":8080", mux); err != nil {
+		fmt.Println("Failed to start server:", err)
+	}
+}
@@ -0,0 +1,138 @@
+// calculator_grpc.go hand-rolls the client/server stubs protoc-gen-go-grpc
+// would generate from proto/calculator.proto; see the package doc in
+// messages.go for why.
+package pb
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// CalculatorServer is the server API for the Calculator service.
+type CalculatorServer interface {
+	Calculate(context.Context, *CalcRequest) (*CalcResponse, error)
+	CalculateStream(*CalcRequest, Calculator_CalculateStreamServer) error
+}
+
+// Calculator_CalculateStreamServer is the stream CalculateStream sends
+// CalcStep frames over.
+type Calculator_CalculateStreamServer interface {
+	Send(*CalcStep) error
+	grpc.ServerStream
+}
+
+type calculatorCalculateStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *calculatorCalculateStreamServer) Send(m *CalcStep) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// RegisterCalculatorServer registers srv with s.
+func RegisterCalculatorServer(s grpc.ServiceRegistrar, srv CalculatorServer) {
+	s.RegisterService(&Calculator_ServiceDesc, srv)
+}
+
+func _Calculator_Calculate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CalcRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServer).Calculate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/calculator.Calculator/Calculate"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServer).Calculate(ctx, req.(*CalcRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Calculator_CalculateStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CalcRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CalculatorServer).CalculateStream(m, &calculatorCalculateStreamServer{stream})
+}
+
+// Calculator_ServiceDesc is the grpc.ServiceDesc for the Calculator service.
+var Calculator_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "calculator.Calculator",
+	HandlerType: (*CalculatorServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Calculate",
+			Handler:    _Calculator_Calculate_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "CalculateStream",
+			Handler:       _Calculator_CalculateStream_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "proto/calculator.proto",
+}
+
+// CalculatorClient is the client API for the Calculator service.
+type CalculatorClient interface {
+	Calculate(ctx context.Context, in *CalcRequest, opts ...grpc.CallOption) (*CalcResponse, error)
+	CalculateStream(ctx context.Context, in *CalcRequest, opts ...grpc.CallOption) (Calculator_CalculateStreamClient, error)
+}
+
+type calculatorClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewCalculatorClient wraps cc with the Calculator service's client stub.
+// Calls need no codec override: wireCodec is registered under gRPC's own
+// "proto" name, so it's what the server already expects by default.
+func NewCalculatorClient(cc grpc.ClientConnInterface) CalculatorClient {
+	return &calculatorClient{cc}
+}
+
+func (c *calculatorClient) Calculate(ctx context.Context, in *CalcRequest, opts ...grpc.CallOption) (*CalcResponse, error) {
+	out := new(CalcResponse)
+	if err := c.cc.Invoke(ctx, "/calculator.Calculator/Calculate", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorClient) CalculateStream(ctx context.Context, in *CalcRequest, opts ...grpc.CallOption) (Calculator_CalculateStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Calculator_ServiceDesc.Streams[0], "/calculator.Calculator/CalculateStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &calculatorCalculateStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// Calculator_CalculateStreamClient is the stream CalculateStream receives
+// CalcStep frames on.
+type Calculator_CalculateStreamClient interface {
+	Recv() (*CalcStep, error)
+	grpc.ClientStream
+}
+
+type calculatorCalculateStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *calculatorCalculateStreamClient) Recv() (*CalcStep, error) {
+	m := new(CalcStep)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
@@ -0,0 +1,46 @@
+package pb
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc/encoding"
+)
+
+// wireMessage is implemented by every message in this package; see wire.go
+// for the protobuf encoding shared between Marshal and Unmarshal.
+type wireMessage interface {
+	Marshal() ([]byte, error)
+	Unmarshal([]byte) error
+}
+
+// wireCodec implements encoding.Codec by calling straight through to each
+// message's own Marshal/Unmarshal. It registers itself under "proto" (see
+// init below), gRPC's own name for the protobuf codec, so this service
+// negotiates exactly like a protoc-generated one would: a real Calculator
+// client built from proto/calculator.proto sends no content-subtype at
+// all, which the server resolves to "proto" and hands to this codec.
+type wireCodec struct{}
+
+func (wireCodec) Marshal(v interface{}) ([]byte, error) {
+	m, ok := v.(wireMessage)
+	if !ok {
+		return nil, fmt.Errorf("pb: cannot marshal %T", v)
+	}
+	return m.Marshal()
+}
+
+func (wireCodec) Unmarshal(data []byte, v interface{}) error {
+	m, ok := v.(wireMessage)
+	if !ok {
+		return fmt.Errorf("pb: cannot unmarshal into %T", v)
+	}
+	return m.Unmarshal(data)
+}
+
+func (wireCodec) Name() string {
+	return "proto"
+}
+
+func init() {
+	encoding.RegisterCodec(wireCodec{})
+}
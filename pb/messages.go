@@ -0,0 +1,190 @@
+// Package pb holds the wire types and gRPC service for the Calculator RPCs
+// described in proto/calculator.proto.
+//
+// protoc and the protoc-gen-go/protoc-gen-go-grpc plugins aren't available
+// in this build environment, so nothing here is generated: CalcRequest,
+// CalcResponse, and CalcStep are plain structs rather than proto.Message
+// implementations, and calculator_grpc.go hand-rolls the client/server
+// stubs protoc-gen-go-grpc would otherwise produce. Their Marshal/Unmarshal
+// methods (wire.go) still hand-encode the real protobuf wire format
+// described by the .proto file — field numbers and types match exactly —
+// and codec.go registers them as gRPC's "proto" codec, so this service
+// interoperates with ordinary protoc-generated Calculator clients on the
+// wire even though these particular structs were written by hand. If
+// protoc becomes available, regenerate from proto/calculator.proto and
+// delete this package's wire.go/codec.go.
+package pb
+
+// CalcRequest is the request message for both Calculator RPCs.
+type CalcRequest struct {
+	Expression string
+	Vars       map[string]float64
+}
+
+// Marshal encodes m as wire-format protobuf matching CalcRequest in
+// proto/calculator.proto: expression as field 1, vars as field 2 (one
+// length-delimited MapEntry{string key=1; double value=2} per entry).
+func (m *CalcRequest) Marshal() ([]byte, error) {
+	var buf []byte
+	buf = appendString(buf, 1, m.Expression)
+	for k, v := range m.Vars {
+		var entry []byte
+		entry = appendString(entry, 1, k)
+		entry = appendDouble(entry, 2, v)
+		buf = appendMessage(buf, 2, entry)
+	}
+	return buf, nil
+}
+
+// Unmarshal decodes wire-format protobuf into m, replacing its contents.
+func (m *CalcRequest) Unmarshal(data []byte) error {
+	*m = CalcRequest{}
+	return eachField(data, func(field, wireType int, raw []byte) error {
+		switch field {
+		case 1:
+			m.Expression = string(raw)
+		case 2:
+			var key string
+			var value float64
+			if err := eachField(raw, func(f, wt int, raw2 []byte) error {
+				switch f {
+				case 1:
+					key = string(raw2)
+				case 2:
+					v, err := decodeDouble(raw2)
+					if err != nil {
+						return err
+					}
+					value = v
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+			if m.Vars == nil {
+				m.Vars = make(map[string]float64)
+			}
+			m.Vars[key] = value
+		}
+		return nil
+	})
+}
+
+func (m *CalcRequest) GetExpression() string {
+	if m != nil {
+		return m.Expression
+	}
+	return ""
+}
+
+func (m *CalcRequest) GetVars() map[string]float64 {
+	if m != nil {
+		return m.Vars
+	}
+	return nil
+}
+
+// CalcResponse is the response message for Calculate.
+type CalcResponse struct {
+	Result float64
+}
+
+// Marshal encodes m as wire-format protobuf matching CalcResponse in
+// proto/calculator.proto: result as field 1.
+func (m *CalcResponse) Marshal() ([]byte, error) {
+	return appendDouble(nil, 1, m.Result), nil
+}
+
+// Unmarshal decodes wire-format protobuf into m, replacing its contents.
+func (m *CalcResponse) Unmarshal(data []byte) error {
+	*m = CalcResponse{}
+	return eachField(data, func(field, wireType int, raw []byte) error {
+		if field == 1 {
+			v, err := decodeDouble(raw)
+			if err != nil {
+				return err
+			}
+			m.Result = v
+		}
+		return nil
+	})
+}
+
+func (m *CalcResponse) GetResult() float64 {
+	if m != nil {
+		return m.Result
+	}
+	return 0
+}
+
+// CalcStep is one frame of a CalculateStream response.
+type CalcStep struct {
+	Op      string
+	A       float64
+	B       float64
+	Partial float64
+}
+
+// Marshal encodes m as wire-format protobuf matching CalcStep in
+// proto/calculator.proto: op as field 1, a/b/partial as fields 2-4.
+func (m *CalcStep) Marshal() ([]byte, error) {
+	var buf []byte
+	buf = appendString(buf, 1, m.Op)
+	buf = appendDouble(buf, 2, m.A)
+	buf = appendDouble(buf, 3, m.B)
+	buf = appendDouble(buf, 4, m.Partial)
+	return buf, nil
+}
+
+// Unmarshal decodes wire-format protobuf into m, replacing its contents.
+func (m *CalcStep) Unmarshal(data []byte) error {
+	*m = CalcStep{}
+	return eachField(data, func(field, wireType int, raw []byte) error {
+		switch field {
+		case 1:
+			m.Op = string(raw)
+		case 2, 3, 4:
+			v, err := decodeDouble(raw)
+			if err != nil {
+				return err
+			}
+			switch field {
+			case 2:
+				m.A = v
+			case 3:
+				m.B = v
+			case 4:
+				m.Partial = v
+			}
+		}
+		return nil
+	})
+}
+
+func (m *CalcStep) GetOp() string {
+	if m != nil {
+		return m.Op
+	}
+	return ""
+}
+
+func (m *CalcStep) GetA() float64 {
+	if m != nil {
+		return m.A
+	}
+	return 0
+}
+
+func (m *CalcStep) GetB() float64 {
+	if m != nil {
+		return m.B
+	}
+	return 0
+}
+
+func (m *CalcStep) GetPartial() float64 {
+	if m != nil {
+		return m.Partial
+	}
+	return 0
+}
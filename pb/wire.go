@@ -0,0 +1,129 @@
+package pb
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// Protobuf wire types used by CalcRequest/CalcResponse/CalcStep: every
+// field in these three messages is either a string, a double, or (for
+// CalcRequest.Vars) a length-delimited submessage, so fixed32 and the
+// deprecated group types never come up.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+)
+
+func appendTag(buf []byte, field, wireType int) []byte {
+	return appendVarint(buf, uint64(field)<<3|uint64(wireType))
+}
+
+func appendVarint(buf []byte, v uint64) []byte {
+	for v >= 0x80 {
+		buf = append(buf, byte(v)|0x80)
+		v >>= 7
+	}
+	return append(buf, byte(v))
+}
+
+// appendString appends field as a length-delimited string, omitted
+// entirely if empty: proto3 never encodes a scalar's zero value.
+func appendString(buf []byte, field int, s string) []byte {
+	if s == "" {
+		return buf
+	}
+	buf = appendTag(buf, field, wireBytes)
+	buf = appendVarint(buf, uint64(len(s)))
+	return append(buf, s...)
+}
+
+// appendDouble appends field as a fixed64, omitted entirely if zero.
+func appendDouble(buf []byte, field int, v float64) []byte {
+	if v == 0 {
+		return buf
+	}
+	buf = appendTag(buf, field, wireFixed64)
+	var raw [8]byte
+	binary.LittleEndian.PutUint64(raw[:], math.Float64bits(v))
+	return append(buf, raw[:]...)
+}
+
+// appendMessage appends field as a length-delimited submessage.
+func appendMessage(buf []byte, field int, inner []byte) []byte {
+	buf = appendTag(buf, field, wireBytes)
+	buf = appendVarint(buf, uint64(len(inner)))
+	return append(buf, inner...)
+}
+
+func consumeVarint(data []byte) (v uint64, n int, err error) {
+	for i := 0; i < len(data) && i < binary.MaxVarintLen64; i++ {
+		b := data[i]
+		v |= uint64(b&0x7f) << (7 * i)
+		if b < 0x80 {
+			return v, i + 1, nil
+		}
+	}
+	return 0, 0, fmt.Errorf("pb: truncated or oversized varint")
+}
+
+// eachField walks data's top-level tag/value pairs, calling fn with the
+// field number, wire type, and raw payload: the encoded varint bytes for
+// wireVarint (unused by these messages, but still consumed so unknown
+// fields of that type can be skipped), the 8 little-endian bytes for
+// wireFixed64, and the inner slice for wireBytes.
+func eachField(data []byte, fn func(field, wireType int, raw []byte) error) error {
+	for len(data) > 0 {
+		tag, n, err := consumeVarint(data)
+		if err != nil {
+			return err
+		}
+		data = data[n:]
+		field := int(tag >> 3)
+		wireType := int(tag & 0x7)
+
+		switch wireType {
+		case wireVarint:
+			_, n, err := consumeVarint(data)
+			if err != nil {
+				return err
+			}
+			if err := fn(field, wireType, data[:n]); err != nil {
+				return err
+			}
+			data = data[n:]
+		case wireFixed64:
+			if len(data) < 8 {
+				return fmt.Errorf("pb: truncated fixed64")
+			}
+			if err := fn(field, wireType, data[:8]); err != nil {
+				return err
+			}
+			data = data[8:]
+		case wireBytes:
+			l, n, err := consumeVarint(data)
+			if err != nil {
+				return err
+			}
+			data = data[n:]
+			if uint64(len(data)) < l {
+				return fmt.Errorf("pb: truncated length-delimited field")
+			}
+			if err := fn(field, wireType, data[:l]); err != nil {
+				return err
+			}
+			data = data[l:]
+		default:
+			return fmt.Errorf("pb: unsupported wire type %d", wireType)
+		}
+	}
+	return nil
+}
+
+func decodeDouble(raw []byte) (float64, error) {
+	if len(raw) != 8 {
+		return 0, fmt.Errorf("pb: invalid fixed64 length %d", len(raw))
+	}
+	return math.Float64frombits(binary.LittleEndian.Uint64(raw)), nil
+}
@@ -0,0 +1,125 @@
+package store
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore is a thread-safe in-memory Store, for use in tests and as a
+// fallback in place of the SQLite backend.
+type MemoryStore struct {
+	mu          sync.Mutex
+	users       []*User
+	tokens      map[string]int64
+	expressions []*Expression
+	nextUserID  int64
+	nextExprID  int64
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{tokens: make(map[string]int64)}
+}
+
+func (s *MemoryStore) CreateUser(ctx context.Context, email, passwordHash string) (*User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, u := range s.users {
+		if u.Email == email {
+			return nil, ErrEmailTaken
+		}
+	}
+
+	s.nextUserID++
+	user := &User{ID: s.nextUserID, Email: email, PasswordHash: passwordHash}
+	s.users = append(s.users, user)
+	return user, nil
+}
+
+func (s *MemoryStore) UserByEmail(ctx context.Context, email string) (*User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, u := range s.users {
+		if u.Email == email {
+			return u, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (s *MemoryStore) CreateToken(ctx context.Context, userID int64, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens[token] = userID
+	return nil
+}
+
+func (s *MemoryStore) UserByToken(ctx context.Context, token string) (*User, error) {
+	s.mu.Lock()
+	userID, ok := s.tokens[token]
+	s.mu.Unlock()
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return s.userByID(userID)
+}
+
+func (s *MemoryStore) userByID(id int64) (*User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, u := range s.users {
+		if u.ID == id {
+			return u, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (s *MemoryStore) SaveExpression(ctx context.Context, userID int64, expr string, result *float64, errMsg *string) (*Expression, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextExprID++
+	e := &Expression{ID: s.nextExprID, UserID: userID, Expr: expr, Result: result, Error: errMsg, CreatedAt: time.Now()}
+	s.expressions = append(s.expressions, e)
+	return e, nil
+}
+
+func (s *MemoryStore) ListExpressions(ctx context.Context, userID int64, limit, offset int) ([]*Expression, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var all []*Expression
+	for i := len(s.expressions) - 1; i >= 0; i-- {
+		if s.expressions[i].UserID == userID {
+			all = append(all, s.expressions[i])
+		}
+	}
+
+	if offset > len(all) {
+		return []*Expression{}, nil
+	}
+	all = all[offset:]
+	if limit >= 0 && limit < len(all) {
+		all = all[:limit]
+	}
+	return all, nil
+}
+
+func (s *MemoryStore) DeleteExpression(ctx context.Context, userID, id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, e := range s.expressions {
+		if e.ID == id && e.UserID == userID {
+			s.expressions = append(s.expressions[:i], s.expressions[i+1:]...)
+			return nil
+		}
+	}
+	return ErrNotFound
+}
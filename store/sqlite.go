@@ -0,0 +1,159 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteStore is a Store backed by SQLite via the pure-Go
+// modernc.org/sqlite driver.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// Open opens (creating if necessary) the SQLite database at path and
+// migrates its schema.
+func Open(path string) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := migrate(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &SQLiteStore{db: db}, nil
+}
+
+func migrate(db *sql.DB) error {
+	_, err := db.Exec(`
+CREATE TABLE IF NOT EXISTS users (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	email TEXT NOT NULL UNIQUE,
+	password_hash TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS tokens (
+	token TEXT PRIMARY KEY,
+	user_id INTEGER NOT NULL REFERENCES users(id),
+	created_at DATETIME NOT NULL
+);
+CREATE TABLE IF NOT EXISTS expressions (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	user_id INTEGER NOT NULL REFERENCES users(id),
+	expr TEXT NOT NULL,
+	result REAL,
+	error TEXT,
+	created_at DATETIME NOT NULL
+);
+`)
+	return err
+}
+
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *SQLiteStore) CreateUser(ctx context.Context, email, passwordHash string) (*User, error) {
+	res, err := s.db.ExecContext(ctx, `INSERT INTO users (email, password_hash) VALUES (?, ?)`, email, passwordHash)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return nil, ErrEmailTaken
+		}
+		return nil, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	return &User{ID: id, Email: email, PasswordHash: passwordHash}, nil
+}
+
+func (s *SQLiteStore) UserByEmail(ctx context.Context, email string) (*User, error) {
+	var u User
+	err := s.db.QueryRowContext(ctx, `SELECT id, email, password_hash FROM users WHERE email = ?`, email).
+		Scan(&u.ID, &u.Email, &u.PasswordHash)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+func (s *SQLiteStore) CreateToken(ctx context.Context, userID int64, token string) error {
+	_, err := s.db.ExecContext(ctx, `INSERT INTO tokens (token, user_id, created_at) VALUES (?, ?, ?)`, token, userID, time.Now())
+	return err
+}
+
+func (s *SQLiteStore) UserByToken(ctx context.Context, token string) (*User, error) {
+	var u User
+	err := s.db.QueryRowContext(ctx, `
+SELECT users.id, users.email, users.password_hash
+FROM tokens JOIN users ON users.id = tokens.user_id
+WHERE tokens.token = ?`, token).Scan(&u.ID, &u.Email, &u.PasswordHash)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+func (s *SQLiteStore) SaveExpression(ctx context.Context, userID int64, expr string, result *float64, errMsg *string) (*Expression, error) {
+	now := time.Now()
+	res, err := s.db.ExecContext(ctx,
+		`INSERT INTO expressions (user_id, expr, result, error, created_at) VALUES (?, ?, ?, ?, ?)`,
+		userID, expr, result, errMsg, now)
+	if err != nil {
+		return nil, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	return &Expression{ID: id, UserID: userID, Expr: expr, Result: result, Error: errMsg, CreatedAt: now}, nil
+}
+
+func (s *SQLiteStore) ListExpressions(ctx context.Context, userID int64, limit, offset int) ([]*Expression, error) {
+	rows, err := s.db.QueryContext(ctx, `
+SELECT id, user_id, expr, result, error, created_at
+FROM expressions
+WHERE user_id = ?
+ORDER BY created_at DESC, id DESC
+LIMIT ? OFFSET ?`, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var exprs []*Expression
+	for rows.Next() {
+		var e Expression
+		if err := rows.Scan(&e.ID, &e.UserID, &e.Expr, &e.Result, &e.Error, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, &e)
+	}
+	return exprs, rows.Err()
+}
+
+func (s *SQLiteStore) DeleteExpression(ctx context.Context, userID, id int64) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM expressions WHERE id = ? AND user_id = ?`, id, userID)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+// Package store persists user accounts, bearer tokens, and per-user
+// expression history behind a Store interface, so a SQLite-backed
+// implementation can be swapped for an in-memory one in tests.
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrNotFound   = errors.New("not found")
+	ErrEmailTaken = errors.New("email already registered")
+)
+
+// User is a registered account.
+type User struct {
+	ID           int64
+	Email        string
+	PasswordHash string
+}
+
+// Expression is one persisted evaluation in a user's history.
+type Expression struct {
+	ID        int64
+	UserID    int64
+	Expr      string
+	Result    *float64
+	Error     *string
+	CreatedAt time.Time
+}
+
+// Store persists users, auth tokens, and per-user expression history.
+type Store interface {
+	CreateUser(ctx context.Context, email, passwordHash string) (*User, error)
+	UserByEmail(ctx context.Context, email string) (*User, error)
+	CreateToken(ctx context.Context, userID int64, token string) error
+	UserByToken(ctx context.Context, token string) (*User, error)
+	SaveExpression(ctx context.Context, userID int64, expr string, result *float64, errMsg *string) (*Expression, error)
+	ListExpressions(ctx context.Context, userID int64, limit, offset int) ([]*Expression, error)
+	DeleteExpression(ctx context.Context, userID, id int64) error
+}
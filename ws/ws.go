@@ -0,0 +1,228 @@
+// Package ws streams expression evaluation over a WebSocket: a client sends
+// expressions frame-by-frame and receives a {op,a,b,partial} event after
+// every reduction, plus a final {result} or {error} frame.
+package ws
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/uncomonq/final-assignment/calc"
+)
+
+const heartbeatInterval = 30 * time.Second
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin:     func(r *http.Request) bool { return true },
+}
+
+// clientMessage is one frame sent by the client: either an expression to
+// evaluate or a cancel instruction for the in-flight evaluation.
+type clientMessage struct {
+	Expression string `json:"expression,omitempty"`
+	Cancel     bool   `json:"cancel,omitempty"`
+}
+
+// stepFrame reports one reduction of the RPN evaluation.
+type stepFrame struct {
+	Op      string  `json:"op"`
+	A       float64 `json:"a"`
+	B       float64 `json:"b"`
+	Partial float64 `json:"partial"`
+}
+
+// resultFrame is the final frame for a successful evaluation.
+type resultFrame struct {
+	Result float64 `json:"result"`
+}
+
+// errorFrame is the final frame for a failed evaluation.
+type errorFrame struct {
+	Error string `json:"error"`
+}
+
+// safeConn serializes writes to a *websocket.Conn. gorilla/websocket allows
+// at most one concurrent writer per connection, but heartbeat and
+// hub.broadcast write to the same conn from different goroutines, so every
+// write has to go through this lock instead of the raw conn.
+type safeConn struct {
+	mu sync.Mutex
+	*websocket.Conn
+}
+
+func (c *safeConn) WriteJSON(v interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.Conn.WriteJSON(v)
+}
+
+func (c *safeConn) WriteMessage(messageType int, data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.Conn.WriteMessage(messageType, data)
+}
+
+// hub fans a single evaluation's frames out to every subscriber watching
+// the same session, so a shared session can be observed by more than one
+// client at once.
+type hub struct {
+	mu          sync.Mutex
+	subscribers map[*safeConn]struct{}
+}
+
+func newHub() *hub {
+	return &hub{subscribers: make(map[*safeConn]struct{})}
+}
+
+func (h *hub) join(conn *safeConn) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.subscribers[conn] = struct{}{}
+}
+
+// leave removes conn from h and reports whether h is now empty, so the
+// caller can decide whether to retire it from the session registry.
+func (h *hub) leave(conn *safeConn) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.subscribers, conn)
+	return len(h.subscribers) == 0
+}
+
+func (h *hub) broadcast(v interface{}) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for conn := range h.subscribers {
+		_ = conn.WriteJSON(v)
+	}
+}
+
+// hubs holds the one hub per session, keyed by the session id in the
+// request path, so that every client watching the same session shares a
+// single evaluation fan-out instead of each connection getting its own.
+var (
+	hubsMu sync.Mutex
+	hubs   = make(map[string]*hub)
+)
+
+// joinSession returns the hub for session, creating it if this is the
+// first subscriber.
+func joinSession(session string, conn *safeConn) *hub {
+	hubsMu.Lock()
+	defer hubsMu.Unlock()
+
+	h, ok := hubs[session]
+	if !ok {
+		h = newHub()
+		hubs[session] = h
+	}
+	h.join(conn)
+	return h
+}
+
+// leaveSession removes conn from session's hub, retiring the hub once its
+// last subscriber has gone.
+func leaveSession(session string, h *hub, conn *safeConn) {
+	if !h.leave(conn) {
+		return
+	}
+
+	hubsMu.Lock()
+	defer hubsMu.Unlock()
+	if hubs[session] == h {
+		delete(hubs, session)
+	}
+}
+
+// Handler upgrades /api/v1/stream/{session} to a WebSocket and evaluates
+// whatever expressions the client sends, streaming a frame after every
+// reduction to every client currently watching the same session.
+func Handler(w http.ResponseWriter, r *http.Request) {
+	rawConn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	conn := &safeConn{Conn: rawConn}
+	defer conn.Close()
+
+	session := r.PathValue("session")
+	h := joinSession(session, conn)
+	defer leaveSession(session, h, conn)
+
+	connCtx, connCancel := context.WithCancel(r.Context())
+	defer connCancel()
+	go heartbeat(connCtx, conn)
+
+	// cancel stops whichever evaluation is currently in flight; it is
+	// replaced every time a new one starts, and guarded by mu since
+	// evaluate runs in its own goroutine while Handler keeps reading.
+	var (
+		mu     sync.Mutex
+		cancel context.CancelFunc = func() {}
+	)
+	defer func() {
+		mu.Lock()
+		defer mu.Unlock()
+		cancel()
+	}()
+
+	for {
+		var msg clientMessage
+		if err := conn.ReadJSON(&msg); err != nil {
+			return
+		}
+
+		if msg.Cancel {
+			mu.Lock()
+			cancel()
+			cancel = func() {}
+			mu.Unlock()
+			continue
+		}
+
+		evalCtx, evalCancel := context.WithCancel(connCtx)
+		mu.Lock()
+		cancel()
+		cancel = evalCancel
+		mu.Unlock()
+
+		go evaluate(evalCtx, h, msg.Expression)
+	}
+}
+
+func heartbeat(ctx context.Context, conn *safeConn) {
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// evaluate runs calc.StepEval on expression, broadcasting a stepFrame after
+// every reduction it reports, then a final resultFrame or errorFrame. This
+// is the same step-by-step engine the gRPC streaming RPC uses, so both
+// transports stay in lockstep with each other.
+func evaluate(ctx context.Context, h *hub, expression string) {
+	result, err := calc.StepEval(ctx, expression, nil, func(step calc.Step) {
+		h.broadcast(stepFrame{Op: step.Op, A: step.A, B: step.B, Partial: step.Partial})
+	})
+	if err != nil {
+		h.broadcast(errorFrame{Error: err.Error()})
+		return
+	}
+	h.broadcast(resultFrame{Result: result})
+}